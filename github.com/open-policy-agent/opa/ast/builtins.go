@@ -0,0 +1,98 @@
+// Copyright 2016 The OPA Authors.  All rights reserved.
+// Use of this source code is governed by an Apache2
+// license that can be found in the LICENSE file.
+
+package ast
+
+// Builtin represents a built-in function supported by OPA. Every built-in
+// function is uniquely identified by a name.
+type Builtin struct {
+	Name string
+}
+
+// DefaultBuiltins is the registry of built-in functions supported by OPA
+// by default. Additional built-ins are appended here via RegisterBuiltin as
+// new source files register them in their own init().
+var DefaultBuiltins []*Builtin
+
+// BuiltinMap provides a convenient mapping of built-in names to built-ins.
+var BuiltinMap = map[string]*Builtin{}
+
+// RegisterBuiltin adds a new built-in function to the registry.
+func RegisterBuiltin(b *Builtin) {
+	DefaultBuiltins = append(DefaultBuiltins, b)
+	BuiltinMap[b.Name] = b
+}
+
+// RuneCount returns the number of runes in a string.
+var RuneCount = &Builtin{
+	Name: "rune_count",
+}
+
+/**
+ * Trim Builtins
+ */
+var (
+
+	// TrimLeft removes leading characters in cutset from a string.
+	TrimLeft = &Builtin{
+		Name: "trim_left",
+	}
+
+	// TrimRight removes trailing characters in cutset from a string.
+	TrimRight = &Builtin{
+		Name: "trim_right",
+	}
+
+	// TrimPrefix removes a leading prefix from a string, once, if present.
+	TrimPrefix = &Builtin{
+		Name: "trim_prefix",
+	}
+
+	// TrimSuffix removes a trailing suffix from a string, once, if present.
+	TrimSuffix = &Builtin{
+		Name: "trim_suffix",
+	}
+
+	// TrimSpace removes leading and trailing white space from a string.
+	TrimSpace = &Builtin{
+		Name: "trim_space",
+	}
+)
+
+/**
+ * Regex Builtins
+ */
+var (
+
+	// RegexSplit splits a string by a regular expression pattern.
+	RegexSplit = &Builtin{
+		Name: "regex.split",
+	}
+
+	// RegexReplace replaces all substrings of a string matching a regular
+	// expression with a replacement string, supporting "$1", "$2", ...
+	// backreferences to captured groups.
+	RegexReplace = &Builtin{
+		Name: "regex.replace",
+	}
+
+	// RegexFindAllStringSubmatchN returns the result of matching a regular
+	// expression against a string up to n times, including submatches. A
+	// value of n less than 0 returns all matches.
+	RegexFindAllStringSubmatchN = &Builtin{
+		Name: "regex.find_all_string_submatch_n",
+	}
+)
+
+func init() {
+	RegisterBuiltin(RuneCount)
+	RegisterBuiltin(TrimLeft)
+	RegisterBuiltin(TrimRight)
+	RegisterBuiltin(TrimPrefix)
+	RegisterBuiltin(TrimSuffix)
+	RegisterBuiltin(TrimSpace)
+	RegisterBuiltin(RegexSplit)
+	RegisterBuiltin(RegexReplace)
+	RegisterBuiltin(RegexFindAllStringSubmatchN)
+}
@@ -0,0 +1,170 @@
+// Copyright 2016 The OPA Authors.  All rights reserved.
+// Use of this source code is governed by an Apache2
+// license that can be found in the LICENSE file.
+
+package topdown
+
+import (
+	"container/list"
+	"regexp"
+	"sync"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/topdown/builtins"
+)
+
+// regexCacheSize bounds the number of compiled regular expressions kept
+// around by regexCache. Policies tend to reuse a small, fixed set of
+// patterns, so an LRU of this size avoids recompiling on every evaluation
+// without growing unbounded for patterns built from dynamic input.
+const regexCacheSize = 100
+
+// regexCache is a size-bounded LRU cache of compiled regular expressions,
+// shared by all regex.* builtins in this file.
+var regexCache = newRegexLRU(regexCacheSize)
+
+type regexLRU struct {
+	mu       sync.Mutex
+	size     int
+	ll       *list.List
+	elements map[string]*list.Element
+}
+
+type regexLRUEntry struct {
+	pattern string
+	re      *regexp.Regexp
+}
+
+func newRegexLRU(size int) *regexLRU {
+	return &regexLRU{
+		size:     size,
+		ll:       list.New(),
+		elements: map[string]*list.Element{},
+	}
+}
+
+func (c *regexLRU) get(pattern string) (*regexp.Regexp, error) {
+	c.mu.Lock()
+	if e, ok := c.elements[pattern]; ok {
+		c.ll.MoveToFront(e)
+		re := e.Value.(*regexLRUEntry).re
+		c.mu.Unlock()
+		return re, nil
+	}
+	c.mu.Unlock()
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e, ok := c.elements[pattern]; ok {
+		c.ll.MoveToFront(e)
+		return e.Value.(*regexLRUEntry).re, nil
+	}
+
+	e := c.ll.PushFront(&regexLRUEntry{pattern: pattern, re: re})
+	c.elements[pattern] = e
+
+	if c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.elements, oldest.Value.(*regexLRUEntry).pattern)
+		}
+	}
+
+	return re, nil
+}
+
+func builtinRegexSplit(a, b ast.Value) (ast.Value, error) {
+	pattern, err := builtins.StringOperand(a, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := builtins.StringOperand(b, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	re, err := regexCache.get(string(pattern))
+	if err != nil {
+		return nil, builtins.NewOperandErr(1, "invalid regex: %v", err)
+	}
+
+	elems := re.Split(string(s), -1)
+	arr := make(ast.Array, len(elems))
+	for i := range elems {
+		arr[i] = ast.StringTerm(elems[i])
+	}
+
+	return arr, nil
+}
+
+func builtinRegexReplace(a, b, c ast.Value) (ast.Value, error) {
+	s, err := builtins.StringOperand(a, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	pattern, err := builtins.StringOperand(b, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	replacement, err := builtins.StringOperand(c, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	re, err := regexCache.get(string(pattern))
+	if err != nil {
+		return nil, builtins.NewOperandErr(2, "invalid regex: %v", err)
+	}
+
+	return ast.String(re.ReplaceAllString(string(s), string(replacement))), nil
+}
+
+func builtinRegexFindAllStringSubmatchN(a, b, c ast.Value) (ast.Value, error) {
+	pattern, err := builtins.StringOperand(a, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := builtins.StringOperand(b, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := builtins.IntOperand(c, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	re, err := regexCache.get(string(pattern))
+	if err != nil {
+		return nil, builtins.NewOperandErr(1, "invalid regex: %v", err)
+	}
+
+	matches := re.FindAllStringSubmatch(string(s), n)
+	arr := make(ast.Array, len(matches))
+	for i, match := range matches {
+		groups := make(ast.Array, len(match))
+		for j := range match {
+			groups[j] = ast.StringTerm(match[j])
+		}
+		arr[i] = ast.ArrayTerm(groups...)
+	}
+
+	return arr, nil
+}
+
+func init() {
+	RegisterFunctionalBuiltin2(ast.RegexSplit.Name, builtinRegexSplit)
+	RegisterFunctionalBuiltin3(ast.RegexReplace.Name, builtinRegexReplace)
+	RegisterFunctionalBuiltin3(ast.RegexFindAllStringSubmatchN.Name, builtinRegexFindAllStringSubmatchN)
+}
@@ -0,0 +1,91 @@
+// Copyright 2016 The OPA Authors.  All rights reserved.
+// Use of this source code is governed by an Apache2
+// license that can be found in the LICENSE file.
+
+package topdown
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/open-policy-agent/opa/ast"
+)
+
+func TestBuiltinRegexSplit(t *testing.T) {
+	result, err := builtinRegexSplit(ast.String(`[,;]\s*`), ast.String("a, b;c"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	arr, ok := result.(ast.Array)
+	if !ok {
+		t.Fatalf("expected ast.Array, got %T", result)
+	}
+
+	expected := []string{"a", "b", "c"}
+	for i, e := range expected {
+		if string(arr[i].Value.(ast.String)) != e {
+			t.Fatalf("expected element %d to be %q, got %v", i, e, arr[i])
+		}
+	}
+}
+
+func TestBuiltinRegexReplace(t *testing.T) {
+	result, err := builtinRegexReplace(ast.String("2023-07-26"), ast.String(`(\d+)-(\d+)-(\d+)`), ast.String("$3/$2/$1"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result != ast.String("26/07/2023") {
+		t.Fatalf("expected %q, got %v", "26/07/2023", result)
+	}
+}
+
+func TestBuiltinRegexFindAllStringSubmatchN(t *testing.T) {
+	result, err := builtinRegexFindAllStringSubmatchN(ast.String(`(\w+)=(\w+)`), ast.String("a=1;b=2"), ast.IntNumberTerm(-1).Value)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	arr, ok := result.(ast.Array)
+	if !ok {
+		t.Fatalf("expected ast.Array, got %T", result)
+	}
+
+	if len(arr) != 2 {
+		t.Fatalf("expected 2 matches, got %d", len(arr))
+	}
+
+	first := arr[0].Value.(ast.Array)
+	got := []string{
+		string(first[0].Value.(ast.String)),
+		string(first[1].Value.(ast.String)),
+		string(first[2].Value.(ast.String)),
+	}
+	want := []string{"a=1", "a", "1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestRegexCacheReusesCompiledPattern(t *testing.T) {
+	re1, err := regexCache.get(`^a+$`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	re2, err := regexCache.get(`^a+$`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if re1 != re2 {
+		t.Fatalf("expected cached regex to be reused")
+	}
+}
+
+func TestRegexSplitInvalidPattern(t *testing.T) {
+	if _, err := builtinRegexSplit(ast.String("("), ast.String("abc")); err == nil {
+		t.Fatalf("expected error for invalid pattern")
+	}
+}
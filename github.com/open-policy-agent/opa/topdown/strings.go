@@ -6,6 +6,7 @@ package topdown
 
 import (
 	"fmt"
+	"math/big"
 	"strings"
 
 	"github.com/open-policy-agent/opa/ast"
@@ -115,14 +116,36 @@ func builtinSubstring(a, b, c ast.Value) (ast.Value, error) {
 		return nil, err
 	}
 
-	var s ast.String
-	if length < 0 {
-		s = ast.String(base[startIndex:])
-	} else {
-		s = ast.String(base[startIndex : startIndex+length])
+	runes := []rune(string(base))
+	n := len(runes)
+
+	if startIndex < 0 {
+		startIndex += n
+	}
+
+	if startIndex < 0 || startIndex > n {
+		return nil, builtins.NewOperandErr(2, "start index %d out of range for string of length %d", startIndex, n)
+	}
+
+	end := n
+	if length >= 0 {
+		if end = startIndex + length; end > n {
+			end = n
+		}
 	}
 
-	return s, nil
+	return ast.String(string(runes[startIndex:end])), nil
+}
+
+// builtinStringLength returns the number of runes in a string, which callers
+// need in order to compute startIndex/length arguments for builtinSubstring.
+func builtinStringLength(a ast.Value) (ast.Value, error) {
+	s, err := builtins.StringOperand(a, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return ast.IntNumberTerm(len([]rune(string(s)))).Value, nil
 }
 
 func builtinContains(a, b ast.Value) (ast.Value, error) {
@@ -244,6 +267,71 @@ func builtinTrim(a, b ast.Value) (ast.Value, error) {
 	return ast.String(strings.Trim(string(s), string(c))), nil
 }
 
+func builtinTrimLeft(a, b ast.Value) (ast.Value, error) {
+	s, err := builtins.StringOperand(a, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := builtins.StringOperand(b, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	return ast.String(strings.TrimLeft(string(s), string(c))), nil
+}
+
+func builtinTrimRight(a, b ast.Value) (ast.Value, error) {
+	s, err := builtins.StringOperand(a, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := builtins.StringOperand(b, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	return ast.String(strings.TrimRight(string(s), string(c))), nil
+}
+
+func builtinTrimPrefix(a, b ast.Value) (ast.Value, error) {
+	s, err := builtins.StringOperand(a, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, err := builtins.StringOperand(b, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	return ast.String(strings.TrimPrefix(string(s), string(prefix))), nil
+}
+
+func builtinTrimSuffix(a, b ast.Value) (ast.Value, error) {
+	s, err := builtins.StringOperand(a, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	suffix, err := builtins.StringOperand(b, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	return ast.String(strings.TrimSuffix(string(s), string(suffix))), nil
+}
+
+func builtinTrimSpace(a ast.Value) (ast.Value, error) {
+	s, err := builtins.StringOperand(a, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return ast.String(strings.TrimSpace(string(s))), nil
+}
+
 func builtinSprintf(a, b ast.Value) (ast.Value, error) {
 	s, err := builtins.StringOperand(a, 1)
 	if err != nil {
@@ -255,17 +343,44 @@ func builtinSprintf(a, b ast.Value) (ast.Value, error) {
 		return nil, builtins.NewOperandTypeErr(2, b, ast.ArrayTypeName)
 	}
 
-	strArr := []interface{}{}
+	args := make([]interface{}, len(astArr))
 	for i := range astArr {
-		if str, ok := astArr[i].Value.(ast.String); ok {
-			strArr = append(strArr, string(str))
-		} else {
-			strArr = append(strArr, astArr[i].Value.String())
+		v, err := sprintfOperand(astArr[i].Value)
+		if err != nil {
+			return nil, err
 		}
+		args[i] = v
 	}
 
-	fmtStr := fmt.Sprintf(string(s), strArr...)
-	return ast.String(fmtStr), nil
+	return ast.String(fmt.Sprintf(string(s), args...)), nil
+}
+
+// sprintfOperand converts v into the concrete Go type that gives fmt
+// verbs (%d, %f, %t, %v, ...) the behaviour a caller would expect,
+// instead of falling back to the AST term's String() representation.
+func sprintfOperand(v ast.Value) (interface{}, error) {
+	switch v := v.(type) {
+	case ast.Number:
+		f := builtins.NumberToFloat(v)
+		if i, acc := f.Int64(); acc == big.Exact {
+			return i, nil
+		}
+		if f.IsInt() {
+			// Integral but too large for an int64: preserve precision.
+			bi, _ := f.Int(nil)
+			return bi, nil
+		}
+		f64, _ := f.Float64()
+		return f64, nil
+	case ast.Boolean:
+		return bool(v), nil
+	case ast.String:
+		return string(v), nil
+	case ast.Array, ast.Object, *ast.Set:
+		return ast.JSON(v)
+	default:
+		return nil, builtins.NewOperandElementErr(2, v, v, ast.NumberTypeName, ast.BooleanTypeName, ast.StringTypeName, ast.ArrayTypeName, ast.ObjectTypeName, ast.SetTypeName)
+	}
 }
 
 func init() {
@@ -273,6 +388,7 @@ func init() {
 	RegisterFunctionalBuiltin2(ast.Concat.Name, builtinConcat)
 	RegisterFunctionalBuiltin2(ast.IndexOf.Name, builtinIndexOf)
 	RegisterFunctionalBuiltin3(ast.Substring.Name, builtinSubstring)
+	RegisterFunctionalBuiltin1(ast.RuneCount.Name, builtinStringLength)
 	RegisterFunctionalBuiltin2(ast.Contains.Name, builtinContains)
 	RegisterFunctionalBuiltin2(ast.StartsWith.Name, builtinStartsWith)
 	RegisterFunctionalBuiltin2(ast.EndsWith.Name, builtinEndsWith)
@@ -281,5 +397,10 @@ func init() {
 	RegisterFunctionalBuiltin2(ast.Split.Name, builtinSplit)
 	RegisterFunctionalBuiltin3(ast.Replace.Name, builtinReplace)
 	RegisterFunctionalBuiltin2(ast.Trim.Name, builtinTrim)
+	RegisterFunctionalBuiltin2(ast.TrimLeft.Name, builtinTrimLeft)
+	RegisterFunctionalBuiltin2(ast.TrimRight.Name, builtinTrimRight)
+	RegisterFunctionalBuiltin2(ast.TrimPrefix.Name, builtinTrimPrefix)
+	RegisterFunctionalBuiltin2(ast.TrimSuffix.Name, builtinTrimSuffix)
+	RegisterFunctionalBuiltin1(ast.TrimSpace.Name, builtinTrimSpace)
 	RegisterFunctionalBuiltin2(ast.Sprintf.Name, builtinSprintf)
 }
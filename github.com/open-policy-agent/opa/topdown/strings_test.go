@@ -0,0 +1,168 @@
+// Copyright 2016 The OPA Authors.  All rights reserved.
+// Use of this source code is governed by an Apache2
+// license that can be found in the LICENSE file.
+
+package topdown
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/open-policy-agent/opa/ast"
+)
+
+func TestBuiltinSprintf(t *testing.T) {
+
+	tests := []struct {
+		note     string
+		format   string
+		args     ast.Array
+		expected string
+	}{
+		{
+			note:     "mixed types",
+			format:   "%v is %d and %t",
+			args:     ast.Array{ast.StringTerm("age"), ast.IntNumberTerm(30), ast.BooleanTerm(true)},
+			expected: "age is 30 and true",
+		},
+		{
+			note:     "big integer with %d",
+			format:   "%d",
+			args:     ast.Array{ast.NumberTerm("100000000000000000000")},
+			expected: "100000000000000000000",
+		},
+		{
+			note:     "float",
+			format:   "%f",
+			args:     ast.Array{ast.NumberTerm("1.5")},
+			expected: "1.500000",
+		},
+		{
+			note:     "hex, octal, binary",
+			format:   "%x %o %b",
+			args:     ast.Array{ast.IntNumberTerm(255), ast.IntNumberTerm(8), ast.IntNumberTerm(5)},
+			expected: "ff 10 101",
+		},
+		{
+			note:     "array via %v",
+			format:   "%v",
+			args:     ast.Array{ast.ArrayTerm(ast.IntNumberTerm(1), ast.IntNumberTerm(2))},
+			expected: "[1 2]",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.note, func(t *testing.T) {
+			result, err := builtinSprintf(ast.String(tc.format), tc.args)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			s, ok := result.(ast.String)
+			if !ok {
+				t.Fatalf("expected ast.String result, got %T", result)
+			}
+			if string(s) != tc.expected {
+				t.Fatalf("expected %q but got %q", tc.expected, string(s))
+			}
+		})
+	}
+}
+
+func TestBuiltinSubstring(t *testing.T) {
+
+	tests := []struct {
+		note     string
+		base     string
+		start    int
+		length   int
+		expected string
+	}{
+		{"ascii", "hello world", 0, 5, "hello"},
+		{"multi-byte utf-8", "héllo", 1, 2, "él"},
+		{"negative offset", "hello", -3, 2, "ll"},
+		{"negative offset whole tail", "hello", -2, -1, "lo"},
+		{"oversize length clamps", "hello", 3, 100, "lo"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.note, func(t *testing.T) {
+			result, err := builtinSubstring(ast.String(tc.base), ast.IntNumberTerm(tc.start).Value, ast.IntNumberTerm(tc.length).Value)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != ast.String(tc.expected) {
+				t.Fatalf("expected %q but got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestBuiltinSubstringOutOfRange(t *testing.T) {
+	if _, err := builtinSubstring(ast.String("hi"), ast.IntNumberTerm(-10).Value, ast.IntNumberTerm(1).Value); err == nil {
+		t.Fatalf("expected an error for negative out-of-range start index")
+	}
+
+	if _, err := builtinSubstring(ast.String("hi"), ast.IntNumberTerm(100).Value, ast.IntNumberTerm(1).Value); err == nil {
+		t.Fatalf("expected an error for positive out-of-range start index")
+	}
+}
+
+func TestBuiltinStringLength(t *testing.T) {
+	result, err := builtinStringLength(ast.String("héllo"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != ast.IntNumberTerm(5).Value {
+		t.Fatalf("expected 5 runes, got %v", result)
+	}
+}
+
+func TestBuiltinTrimFamily(t *testing.T) {
+
+	tests := []struct {
+		note     string
+		f        func(a, b ast.Value) (ast.Value, error)
+		s        string
+		arg      string
+		expected string
+	}{
+		{"trim_left", builtinTrimLeft, "xxhelloxx", "x", "helloxx"},
+		{"trim_right", builtinTrimRight, "xxhelloxx", "x", "xxhello"},
+		{"trim_prefix match", builtinTrimPrefix, "/api/v1/foo", "/api/v1", "/foo"},
+		{"trim_prefix no match", builtinTrimPrefix, "/foo", "/api/v1", "/foo"},
+		{"trim_suffix match", builtinTrimSuffix, "foo.json", ".json", "foo"},
+		{"trim_suffix no match", builtinTrimSuffix, "foo.json", ".yaml", "foo.json"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.note, func(t *testing.T) {
+			result, err := tc.f(ast.String(tc.s), ast.String(tc.arg))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != ast.String(tc.expected) {
+				t.Fatalf("expected %q but got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestBuiltinTrimSpace(t *testing.T) {
+	result, err := builtinTrimSpace(ast.String("  hello world  \n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != ast.String("hello world") {
+		t.Fatalf("expected %q but got %v", "hello world", result)
+	}
+}
+
+func TestSprintfOperandBigInt(t *testing.T) {
+	v, err := sprintfOperand(ast.Number("100000000000000000000"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := v.(*big.Int); !ok {
+		t.Fatalf("expected *big.Int for oversized integral number, got %T", v)
+	}
+}